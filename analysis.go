@@ -10,9 +10,11 @@ import (
 	"bufio"
 	"os"
 	"io"
+	"runtime"
 
 	// Third party packages
 	"github.com/gookit/color"
+	"golang.org/x/sync/errgroup"
 )
 
 
@@ -27,8 +29,10 @@ import (
 type Chain struct {
 	ID           int        // The chain identifier
 	Prio         int        // The chain priority
+	Executor     int        // The executor this chain is assigned to; see AnalyseSchedulability
 	Path         []int      // The callbacks that consist the chain
 	Period_us    int64      // The period of the chain timer (microseconds)
+	Deadline_us  int64      // The chain deadline (microseconds); if 0, Period_us is used
 	Utilisation  float64    // Chain specific utilisation
 	Random_seed  int        // Seed used when generating this chain
 	PPE          bool       // [Test setting]: Whether the chain runs on the PPE or not
@@ -42,12 +46,29 @@ type Chain struct {
 // Type describing a slice of chains
 type Chains []Chain
 
+// A single point on a chain's cumulative response-time distribution
+type CDFPoint struct {
+	T_us        int64   // Response time (microseconds)
+	CumFraction float64 // Fraction of samples at or below T_us
+}
+
 // Type describing a chain analysis
 type Result struct {
-	ID            int       // The chain identifier
-	WCRT_us       int64     // The worst case response time (microseconds)
-	ACRT_us       int64     // Average case response time (microseconds)
-	BCRT_us       int64     // Best case response time (microseconds)
+	ID                int       // The chain identifier
+	WCRT_us           int64     // The worst case response time (microseconds)
+	ACRT_us           int64     // Average case response time (microseconds)
+	BCRT_us           int64     // Best case response time (microseconds)
+	P50_us            int64     // Median response time (microseconds)
+	P95_us            int64     // 95th percentile response time (microseconds)
+	P99_us            int64     // 99th percentile response time (microseconds)
+	P999_us           int64     // 99.9th percentile response time (microseconds)
+	Stddev_us         float64   // Standard deviation of response times (microseconds)
+	Jitter_us         int64     // WCRT_us - BCRT_us
+	Samples           int64     // Number of response times the result was derived from
+	DeadlineMisses    int64     // Number of response times exceeding the chain's deadline
+	DeadlineMissRatio float64   // DeadlineMisses / Samples
+	CDF               []CDFPoint // Cumulative distribution table; only set when AnalyseOptions.EmitCDF is true
+	Schedulable       bool      // Set by AnalyseSchedulability: whether the analytic WCRT bound is within Period_us
 }
 
 // Type describing a log call event
@@ -189,52 +210,76 @@ func ReadEvents (filepath string) ([]Event, error) {
 	return events, nil
 }
 
-// Converts (Chains, Logfile) into results
+// Options controlling how Analyse is carried out
+type AnalyseOptions struct {
+	Workers int  // Maximum number of chains analysed concurrently (default: runtime.GOMAXPROCS(0))
+	EmitCDF bool // When set, populate Result.CDF with a per-chain cumulative distribution table
+}
+
+// Converts (Chains, Logfile) into results, using default options
 func Analyse (chains Chains, events []Event) []Result {
-	var results []Result = []Result{}
+	results, err := AnalyseWithOptions(chains, events, AnalyseOptions{})
+	if nil != err {
+		return []Result{}
+	}
+	return results
+}
+
+// Converts (Chains, Logfile) into results, honouring the supplied options.
+// Events are bucketed by chain ID in a single pass, then each chain is
+// analysed (and its BCRT/WCRT/ACRT reduced) concurrently across a worker
+// pool. Results are returned in the same order as the input chains.
+func AnalyseWithOptions (chains Chains, events []Event, opts AnalyseOptions) ([]Result, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
 
-	for _, chain := range chains {
+	// Bucket events by chain ID in a single pass
+	events_by_chain := make(map[int][]Event, len(chains))
+	for _, event := range events {
+		events_by_chain[event.Chain] = append(events_by_chain[event.Chain], event)
+	}
 
-		// Collect all events related to the chain
-		chain_events := []Event{}
-		for _, event := range events {
-			if event.Chain == chain.ID {
-				chain_events = append(chain_events, event)
-			}
-		}
-		fmt.Fprintf(os.Stderr, "Analyzing chain %d (%d events)\n", chain.ID, len(chain_events))
+	// Slot per chain, filled in concurrently; nil means no response times
+	slots := make([]*Result, len(chains))
 
-		// Obtain all response times
-		response_times := analyse_chain(chain, chain_events)
+	var g errgroup.Group
+	g.SetLimit(workers)
 
-		// If there were no response times, do nothing
-		if len(response_times) == 0 {
-			fmt.Fprintf(os.Stderr, "No response times were computed for chain %d\n", chain.ID)
-			continue
-		}
+	for i, chain := range chains {
+		i, chain := i, chain
+		g.Go(func() error {
+			chain_events := events_by_chain[chain.ID]
+			fmt.Fprintf(os.Stderr, "Analyzing chain %d (%d events)\n", chain.ID, len(chain_events))
 
-		// Calculate the BCRT, WCRT, and ACRT
-		bcrt, wcrt, acrt := response_times[0], response_times[0], response_times[0]
-		for i := 1; i < len(response_times); i++ {
-			if response_times[i] < bcrt {
-				bcrt = response_times[i]
-			}
-			if response_times[i] > wcrt {
-				wcrt = response_times[i]
+			// Obtain all response times
+			response_times := analyse_chain(chain, chain_events)
+
+			// If there were no response times, do nothing
+			if len(response_times) == 0 {
+				fmt.Fprintf(os.Stderr, "No response times were computed for chain %d\n", chain.ID)
+				return nil
 			}
-			acrt += response_times[i]
-		}
-		acrt /= int64(len(response_times))
 
-		results = append(results, Result{
-			ID:       chain.ID,
-			WCRT_us:  wcrt,
-			ACRT_us:  acrt,
-			BCRT_us:  bcrt,
+			result := reduce_response_times(chain, response_times, opts)
+			slots[i] = &result
+			return nil
 		})
 	}
 
-	return results
+	if err := g.Wait(); nil != err {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(chains))
+	for _, slot := range slots {
+		if nil != slot {
+			results = append(results, *slot)
+		}
+	}
+
+	return results, nil
 }
 
 /*
@@ -255,6 +300,54 @@ func analyse_chain (chain Chain, events []Event) []int64 {
 	return response_times
 }
 
+// Reduces a chain's response times into a Result: BCRT/WCRT/ACRT, percentile
+// and jitter statistics, and the deadline-miss ratio. The deadline is
+// chain.Deadline_us, falling back to chain.Period_us when unset.
+func reduce_response_times (chain Chain, response_times []int64, opts AnalyseOptions) Result {
+	bcrt, wcrt, acrt := response_times[0], response_times[0], response_times[0]
+	for i := 1; i < len(response_times); i++ {
+		if response_times[i] < bcrt {
+			bcrt = response_times[i]
+		}
+		if response_times[i] > wcrt {
+			wcrt = response_times[i]
+		}
+		acrt += response_times[i]
+	}
+	acrt /= int64(len(response_times))
+
+	deadline_us := chain.Deadline_us
+	if deadline_us == 0 {
+		deadline_us = chain.Period_us
+	}
+
+	var misses int64
+	for _, rt := range response_times {
+		if rt > deadline_us {
+			misses++
+		}
+	}
+
+	stats := compute_stats(response_times, opts.EmitCDF)
+
+	return Result{
+		ID:                chain.ID,
+		WCRT_us:           wcrt,
+		ACRT_us:           acrt,
+		BCRT_us:           bcrt,
+		P50_us:            stats.P50_us,
+		P95_us:            stats.P95_us,
+		P99_us:            stats.P99_us,
+		P999_us:           stats.P999_us,
+		Stddev_us:         stats.Stddev_us,
+		Jitter_us:         wcrt - bcrt,
+		Samples:           int64(len(response_times)),
+		DeadlineMisses:    misses,
+		DeadlineMissRatio: float64(misses) / float64(len(response_times)),
+		CDF:               stats.CDF,
+	}
+}
+
 func parse_event (line []byte) (Event, error) {
 	var event Event
 	var split int = 0
@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"testing"
+)
+
+
+// Chain work shrinks as ID grows, so under a small worker pool later chains
+// tend to finish first; results must still come back in input chain order.
+func TestAnalyseWithOptionsPreservesOrder (t *testing.T) {
+	var chains Chains
+	var events []Event
+
+	for id := 0; id < 8; id++ {
+		chains = append(chains, Chain{ID: id, Period_us: 1000})
+		for n := 0; n < (8-id)*50; n++ {
+			events = append(events, Event{Chain: id, Duration_us: int64(n + 1)})
+		}
+	}
+
+	results, err := AnalyseWithOptions(chains, events, AnalyseOptions{Workers: 4})
+	if nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != len(chains) {
+		t.Fatalf("expected %d results, got %d", len(chains), len(results))
+	}
+
+	for i, result := range results {
+		if result.ID != chains[i].ID {
+			t.Fatalf("result %d: expected chain ID %d, got %d", i, chains[i].ID, result.ID)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package analysis
+
+import (
+	// Standard packages
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+
+/*
+ *******************************************************************************
+ *                              Type Definitions                               *
+ *******************************************************************************
+*/
+
+
+// Decodes a single line of an event log into an Event
+type EventDecoder interface {
+	Decode (line []byte) (Event, error)
+}
+
+// Decodes events in the original brace-delimited log format:
+// {executor: %d, chain: %d, start: %d, duration: %d}
+type BraceDecoder struct{}
+
+// Decodes events in newline-delimited JSON:
+// {"executor":%d,"chain":%d,"start_us":%d,"duration_us":%d}
+type JSONDecoder struct{}
+
+// Decodes events in CSV form: executor,chain,start_us,duration_us
+type CSVDecoder struct{}
+
+// Mirrors Event, but with JSON tags matching the newline-delimited format
+type json_event struct {
+	Executor    int   `json:"executor"`
+	Chain       int   `json:"chain"`
+	Start_us    int64 `json:"start_us"`
+	Duration_us int64 `json:"duration_us"`
+}
+
+
+/*
+ *******************************************************************************
+ *                         Public Function Definitions                         *
+ *******************************************************************************
+*/
+
+
+// Decodes a line in the brace-delimited format
+func (BraceDecoder) Decode (line []byte) (Event, error) {
+	return parse_event(line)
+}
+
+// Decodes a line of newline-delimited JSON
+func (JSONDecoder) Decode (line []byte) (Event, error) {
+	var je json_event
+
+	if err := json.Unmarshal(line, &je); nil != err {
+		return Event{}, errors.New("Unable to parse JSON event: " + err.Error())
+	}
+
+	return Event{
+		Executor:    je.Executor,
+		Chain:       je.Chain,
+		Start_us:    je.Start_us,
+		Duration_us: je.Duration_us,
+	}, nil
+}
+
+// Decodes a line of CSV: executor,chain,start_us,duration_us
+func (CSVDecoder) Decode (line []byte) (Event, error) {
+	var event Event
+
+	r := csv.NewReader(strings.NewReader(string(line)))
+	r.FieldsPerRecord = 4
+
+	fields, err := r.Read()
+	if nil != err {
+		return event, errors.New("Unable to parse CSV event: " + err.Error())
+	}
+
+	values := make([]int64, 4)
+	for i, field := range fields {
+		value, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if nil != err {
+			return event, fmt.Errorf("Unable to parse CSV field %d (%q): %s", i, field, err.Error())
+		}
+		values[i] = value
+	}
+
+	event.Executor = int(values[0])
+	event.Chain = int(values[1])
+	event.Start_us = values[2]
+	event.Duration_us = values[3]
+
+	return event, nil
+}
+
+// Attempts to read a file into a series of events, using the given decoder
+func ReadEventsFormat (filepath string, dec EventDecoder) ([]Event, error) {
+	file, err := os.Open(filepath)
+	if nil != err {
+		return []Event{}, errors.New("Unable to open " + filepath + ": " + err.Error())
+	}
+	defer file.Close()
+
+	events := []Event{}
+
+	in, errs := StreamEvents(file, dec)
+	for event := range in {
+		events = append(events, event)
+	}
+
+	if err := <-errs; nil != err {
+		return []Event{}, err
+	}
+
+	return events, nil
+}
+
+// Streams events decoded from r, one line at a time, with bounded memory.
+// The returned error channel carries at most one error, sent once decoding
+// stops (at EOF, in which case it carries nil; on a read error, including a
+// line longer than the 16 MiB scan buffer; or on the first decode failure);
+// both channels are closed once draining in is complete.
+func StreamEvents (r io.Reader, dec EventDecoder) (<-chan Event, <-chan error) {
+	out := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+		for n := 1; scanner.Scan(); n++ {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			event, err := dec.Decode(line)
+			if nil != err {
+				errs <- fmt.Errorf("line %d: %s", n, err.Error())
+				return
+			}
+
+			out <- event
+		}
+
+		errs <- scanner.Err()
+	}()
+
+	return out, errs
+}
+
+// Opens filepath and reads its events, auto-detecting the decoder from the
+// file extension (.json/.ndjson -> JSONDecoder, .csv -> CSVDecoder, anything
+// else -> the original brace-delimited BraceDecoder)
+func OpenEvents (filepath string) ([]Event, error) {
+	ext := ""
+	for i := len(filepath) - 1; i >= 0 && filepath[i] != '/'; i-- {
+		if filepath[i] == '.' {
+			ext = filepath[i:]
+			break
+		}
+	}
+
+	var dec EventDecoder
+	switch ext {
+	case ".json", ".ndjson":
+		dec = JSONDecoder{}
+	case ".csv":
+		dec = CSVDecoder{}
+	default:
+		dec = BraceDecoder{}
+	}
+
+	return ReadEventsFormat(filepath, dec)
+}
+
+// Converts a stream of events into results, folding each event into a
+// per-chain running min/max/sum/count without materializing the full
+// event slice. Events may arrive interleaved across chains and in any order.
+func AnalyseStream (chains Chains, in <-chan Event) []Result {
+	type running struct {
+		bcrt, wcrt, sum int64
+		count           int64
+	}
+
+	order := make([]int, len(chains))
+	state := make(map[int]*running, len(chains))
+	for i, chain := range chains {
+		order[i] = chain.ID
+		state[chain.ID] = nil
+	}
+
+	for event := range in {
+		r, known := state[event.Chain]
+		if !known {
+			continue
+		}
+
+		if nil == r {
+			r = &running{bcrt: event.Duration_us, wcrt: event.Duration_us}
+			state[event.Chain] = r
+		}
+
+		if event.Duration_us < r.bcrt {
+			r.bcrt = event.Duration_us
+		}
+		if event.Duration_us > r.wcrt {
+			r.wcrt = event.Duration_us
+		}
+		r.sum += event.Duration_us
+		r.count++
+	}
+
+	results := make([]Result, 0, len(chains))
+	for _, id := range order {
+		r := state[id]
+		if nil == r || r.count == 0 {
+			continue
+		}
+
+		results = append(results, Result{
+			ID:      id,
+			WCRT_us: r.wcrt,
+			ACRT_us: r.sum / r.count,
+			BCRT_us: r.bcrt,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	// Standard packages
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+
+func TestBraceDecoder (t *testing.T) {
+	event, err := BraceDecoder{}.Decode([]byte("{executor: 1, chain: 2, start: 3, duration: 4}"))
+	if nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Event{Executor: 1, Chain: 2, Start_us: 3, Duration_us: 4}
+	if event != want {
+		t.Fatalf("expected %+v, got %+v", want, event)
+	}
+}
+
+func TestBraceDecoderMalformed (t *testing.T) {
+	var dec BraceDecoder
+	if _, err := dec.Decode([]byte("not an event")); nil == err {
+		t.Fatalf("expected an error for a malformed line")
+	}
+}
+
+func TestJSONDecoder (t *testing.T) {
+	line := []byte(`{"executor":1,"chain":2,"start_us":3,"duration_us":4}`)
+	event, err := JSONDecoder{}.Decode(line)
+	if nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Event{Executor: 1, Chain: 2, Start_us: 3, Duration_us: 4}
+	if event != want {
+		t.Fatalf("expected %+v, got %+v", want, event)
+	}
+}
+
+func TestJSONDecoderMalformed (t *testing.T) {
+	var dec JSONDecoder
+	if _, err := dec.Decode([]byte(`{"executor":`)); nil == err {
+		t.Fatalf("expected an error for malformed JSON")
+	}
+}
+
+func TestCSVDecoder (t *testing.T) {
+	event, err := CSVDecoder{}.Decode([]byte("1,2,3,4"))
+	if nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Event{Executor: 1, Chain: 2, Start_us: 3, Duration_us: 4}
+	if event != want {
+		t.Fatalf("expected %+v, got %+v", want, event)
+	}
+}
+
+func TestCSVDecoderMalformed (t *testing.T) {
+	var dec CSVDecoder
+	if _, err := dec.Decode([]byte("1,2,3")); nil == err {
+		t.Fatalf("expected an error for a short CSV record")
+	}
+	if _, err := dec.Decode([]byte("1,2,3,not-a-number")); nil == err {
+		t.Fatalf("expected an error for a non-numeric CSV field")
+	}
+}
+
+// A decode failure partway through the stream must surface on the error
+// channel, and the event channel must close rather than hang, so that
+// ranging over it (as ReadEventsFormat does) always terminates.
+func TestStreamEventsPropagatesDecodeError (t *testing.T) {
+	input := "{executor: 0, chain: 0, start: 0, duration: 5}\n" +
+		"not a valid line\n" +
+		"{executor: 0, chain: 0, start: 0, duration: 6}\n"
+
+	in, errs := StreamEvents(strings.NewReader(input), BraceDecoder{})
+
+	var got []Event
+	for event := range in {
+		got = append(got, event)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event before the bad line, got %d", len(got))
+	}
+	if got[0].Duration_us != 5 {
+		t.Fatalf("expected the first event's duration to be 5, got %d", got[0].Duration_us)
+	}
+
+	if err := <-errs; nil == err {
+		t.Fatalf("expected a decode error on the error channel")
+	}
+}
+
+func TestStreamEventsSkipsBlankLines (t *testing.T) {
+	input := "{executor: 0, chain: 0, start: 0, duration: 1}\n\n{executor: 0, chain: 0, start: 0, duration: 2}\n"
+
+	in, errs := StreamEvents(strings.NewReader(input), BraceDecoder{})
+
+	var got []Event
+	for event := range in {
+		got = append(got, event)
+	}
+
+	if err := <-errs; nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+}
+
+// OpenEvents must pick a decoder from the file extension: .json/.ndjson ->
+// JSONDecoder, .csv -> CSVDecoder, anything else (including no extension) ->
+// BraceDecoder
+func TestOpenEventsDispatchesByExtension (t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name       string
+		content    string
+		want_chain int
+	}{
+		{"events.json", `{"executor":0,"chain":7,"start_us":0,"duration_us":1}` + "\n", 7},
+		{"events.ndjson", `{"executor":0,"chain":8,"start_us":0,"duration_us":1}` + "\n", 8},
+		{"events.csv", "0,9,0,1\n", 9},
+		{"events.log", "{executor: 0, chain: 10, start: 0, duration: 1}\n", 10},
+		{"events", "{executor: 0, chain: 11, start: 0, duration: 1}\n", 11},
+	}
+
+	for _, c := range cases {
+		path := filepath.Join(dir, c.name)
+		if err := os.WriteFile(path, []byte(c.content), 0644); nil != err {
+			t.Fatalf("unable to write fixture %s: %v", c.name, err)
+		}
+
+		events, err := OpenEvents(path)
+		if nil != err {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if len(events) != 1 || events[0].Chain != c.want_chain {
+			t.Fatalf("%s: expected a single event for chain %d, got %+v", c.name, c.want_chain, events)
+		}
+	}
+}
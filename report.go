@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	// Standard packages
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	// Third party packages
+	"github.com/gookit/color"
+)
+
+
+/*
+ *******************************************************************************
+ *                               Private Constants                             *
+ *******************************************************************************
+*/
+
+
+// A chain is considered "tight" (rendered yellow in WriteTextReport) once
+// its WCRT crosses this fraction of its period, short of actually missing it
+const schedulability_margin = 0.9
+
+
+/*
+ *******************************************************************************
+ *                         Public Function Definitions                         *
+ *******************************************************************************
+*/
+
+
+// Writes a GitHub-Actions-style markdown step summary table for results,
+// joined against chains for path and period. Rows where WCRT_us exceeds the
+// chain's period are marked with a warning, so schedulability regressions
+// stand out in a CI diff.
+func WriteMarkdownReport (w io.Writer, chains Chains, results []Result) error {
+	by_id := chains_by_id(chains)
+
+	if _, err := fmt.Fprintf(w, "| Chain | Path | Period (us) | BCRT (us) | ACRT (us) | WCRT (us) | P99 (us) | Deadline miss %% | |\n"); nil != err {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "|---|---|---|---|---|---|---|---|---|\n"); nil != err {
+		return err
+	}
+
+	for _, result := range results {
+		chain := by_id[result.ID]
+
+		marker := ""
+		if result.WCRT_us > chain.Period_us {
+			marker = "⚠️"
+		}
+
+		_, err := fmt.Fprintf(w, "| %d | %s | %d | %d | %d | %d | %d | %.2f%% | %s |\n",
+			result.ID, Path2String(chain.Path), chain.Period_us,
+			result.BCRT_us, result.ACRT_us, result.WCRT_us, result.P99_us,
+			result.DeadlineMissRatio*100, marker)
+		if nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Writes a plain-text table of results for a TTY, one line per chain,
+// colored by schedulability: green when comfortably within period, yellow
+// once WCRT exceeds schedulability_margin times the period, red once the
+// period is exceeded.
+func WriteTextReport (w io.Writer, chains Chains, results []Result) error {
+	by_id := chains_by_id(chains)
+
+	for _, result := range results {
+		chain := by_id[result.ID]
+
+		line := fmt.Sprintf(
+			"chain %-4d %-24s period=%-10d bcrt=%-10d acrt=%-10d wcrt=%-10d p99=%-10d miss=%.2f%%",
+			result.ID, Path2String(chain.Path), chain.Period_us,
+			result.BCRT_us, result.ACRT_us, result.WCRT_us, result.P99_us,
+			result.DeadlineMissRatio*100)
+
+		style := schedulability_style(chain, result)
+
+		if _, err := fmt.Fprintln(w, style.Sprint(line)); nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Appends a markdown report to the file named by the GITHUB_STEP_SUMMARY
+// environment variable. A no-op when the variable is unset, so call sites
+// can invoke it unconditionally in both CI and local runs.
+func AppendStepSummary (chains Chains, results []Result) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if nil != err {
+		return errors.New("Unable to open " + path + ": " + err.Error())
+	}
+	defer file.Close()
+
+	return WriteMarkdownReport(file, chains, results)
+}
+
+
+/*
+ *******************************************************************************
+ *                        Private Function Definitions                        *
+ *******************************************************************************
+*/
+
+
+// Indexes chains by ID for joining against results
+func chains_by_id (chains Chains) map[int]Chain {
+	by_id := make(map[int]Chain, len(chains))
+	for _, chain := range chains {
+		by_id[chain.ID] = chain
+	}
+	return by_id
+}
+
+// Picks the color.Style describing a result's schedulability
+func schedulability_style (chain Chain, result Result) color.Style {
+	switch {
+	case result.WCRT_us > chain.Period_us:
+		return color.Style{color.FgRed, color.OpBold}
+	case float64(result.WCRT_us) > schedulability_margin*float64(chain.Period_us):
+		return color.Style{color.FgYellow, color.OpBold}
+	default:
+		return color.Style{color.FgGreen, color.OpBold}
+	}
+}
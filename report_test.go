@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	// Standard packages
+	"strings"
+	"testing"
+
+	// Third party packages
+	"github.com/gookit/color"
+)
+
+
+func TestWriteMarkdownReport (t *testing.T) {
+	chains := Chains{
+		{ID: 1, Path: []int{1, 2}, Period_us: 100},
+		{ID: 2, Path: []int{3}, Period_us: 50},
+	}
+	results := []Result{
+		{ID: 1, BCRT_us: 10, ACRT_us: 20, WCRT_us: 30, P99_us: 28, DeadlineMissRatio: 0},
+		{ID: 2, BCRT_us: 40, ACRT_us: 55, WCRT_us: 70, P99_us: 65, DeadlineMissRatio: 0.25},
+	}
+
+	var buf strings.Builder
+	if err := WriteMarkdownReport(&buf, chains, results); nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+
+	if len(lines) != 4 {
+		t.Fatalf("expected a header, separator and 2 data rows (4 lines), got %d:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], "Deadline miss %") {
+		t.Fatalf("expected header to contain an escaped 'Deadline miss %%' column, got %q", lines[0])
+	}
+
+	// Chain 1: WCRT_us (30) <= Period_us (100), no warning marker
+	if !strings.Contains(lines[2], "| 1 | {1,2} | 100 | 10 | 20 | 30 | 28 | 0.00% |") {
+		t.Fatalf("unexpected chain 1 row: %q", lines[2])
+	}
+	if strings.Contains(lines[2], "⚠️") {
+		t.Fatalf("chain 1 is within its period and should not carry a warning marker: %q", lines[2])
+	}
+
+	// Chain 2: WCRT_us (70) > Period_us (50), must carry the warning marker
+	if !strings.Contains(lines[3], "| 2 | {3} | 50 | 40 | 55 | 70 | 65 | 25.00% |") {
+		t.Fatalf("unexpected chain 2 row: %q", lines[3])
+	}
+	if !strings.Contains(lines[3], "⚠️") {
+		t.Fatalf("chain 2 exceeds its period and must carry the warning marker: %q", lines[3])
+	}
+}
+
+func TestWriteTextReportContainsFields (t *testing.T) {
+	chains := Chains{{ID: 1, Path: []int{1}, Period_us: 100}}
+	results := []Result{{ID: 1, BCRT_us: 10, ACRT_us: 20, WCRT_us: 30, P99_us: 28}}
+
+	var buf strings.Builder
+	if err := WriteTextReport(&buf, chains, results); nil != err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"chain 1", "period=100", "bcrt=10", "wcrt=30", "p99=28"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+// schedulability_style must pick green comfortably within period, yellow
+// once WCRT crosses schedulability_margin of the period, and red once the
+// period is actually exceeded.
+func TestSchedulabilityStyleBoundaries (t *testing.T) {
+	chain := Chain{Period_us: 100} // schedulability_margin boundary sits at WCRT_us == 90
+
+	cases := []struct {
+		wcrt_us int64
+		want    color.Style
+	}{
+		{89, color.Style{color.FgGreen, color.OpBold}},
+		{90, color.Style{color.FgGreen, color.OpBold}},  // not yet > margin*period
+		{91, color.Style{color.FgYellow, color.OpBold}}, // past the margin, still <= period
+		{100, color.Style{color.FgYellow, color.OpBold}}, // at the period boundary, not yet >
+		{101, color.Style{color.FgRed, color.OpBold}},    // past the period
+	}
+
+	for _, c := range cases {
+		result := Result{WCRT_us: c.wcrt_us}
+		got := schedulability_style(chain, result)
+
+		if len(got) != len(c.want) || got[0] != c.want[0] || got[1] != c.want[1] {
+			t.Fatalf("WCRT_us=%d: expected style %v, got %v", c.wcrt_us, c.want, got)
+		}
+	}
+}
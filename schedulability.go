@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	// Standard packages
+	"fmt"
+)
+
+
+/*
+ *******************************************************************************
+ *                         Public Function Definitions                         *
+ *******************************************************************************
+*/
+
+
+// Computes a fixed-priority preemptive response-time analysis bound per
+// chain, using Chains alone (no trace required). For chain i with WCET C_i
+// and period T_i, the classic recurrence
+//
+//	R_i^(n+1) = C_i + sum_{j in hp(i)} ceil(R_i^(n) / T_j) * C_j
+//
+// is iterated from R_i^(0) = C_i until it converges (schedulable, R_i <= T_i)
+// or overshoots the period (unschedulable). hp(i) is the set of chains on
+// the same Executor with a higher Prio than chain i. A chain's WCET is
+// wcets[chain.ID] when present, otherwise derived as
+// chain.Utilisation * chain.Period_us; an error is returned if neither is
+// available for chain i or any chain in hp(i).
+//
+// The model assumes a strict priority order per Executor: two chains
+// sharing both Executor and Prio make hp(i) ambiguous (neither preempts the
+// other, so which one interferes with the rest of the system is undefined),
+// so that combination is rejected with an error rather than silently
+// picking a tie-break.
+//
+// Chain.Executor is not populated by WriteChains/ReadChains; it defaults to
+// the zero value, which puts every chain on the same executor. Callers
+// building Chains through that path must assign Executor themselves before
+// passing the result here.
+func AnalyseSchedulability (chains Chains, wcets map[int]int64) ([]Result, error) {
+	if err := validate_distinct_priorities(chains); nil != err {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(chains))
+
+	for _, chain := range chains {
+		c_i, ok := wcet_for(chain, wcets)
+		if !ok {
+			return nil, fmt.Errorf("no WCET for chain %d: pass wcets[%d] or set Chain.Utilisation",
+				chain.ID, chain.ID)
+		}
+
+		hp := higher_priority_chains(chains, chain)
+
+		r_i := c_i
+		for {
+			interference := int64(0)
+			for _, j := range hp {
+				c_j, ok := wcet_for(j, wcets)
+				if !ok {
+					return nil, fmt.Errorf("no WCET for chain %d: pass wcets[%d] or set Chain.Utilisation",
+						j.ID, j.ID)
+				}
+				interference += ceil_div(r_i, j.Period_us) * c_j
+			}
+
+			next := c_i + interference
+			if next == r_i || next > chain.Period_us {
+				r_i = next
+				break
+			}
+			r_i = next
+		}
+
+		results = append(results, Result{
+			ID:          chain.ID,
+			WCRT_us:     r_i,
+			Schedulable: r_i <= chain.Period_us,
+		})
+	}
+
+	return results, nil
+}
+
+// Cross-checks measured results (e.g. from Analyse) against an analytic
+// schedulability bound (e.g. from AnalyseSchedulability) and returns the IDs
+// of chains whose measured WCRT exceeded the analytic bound -- a sign that
+// the model in AnalyseSchedulability is missing a source of interference.
+func CrossCheckSchedulability (measured []Result, analytic []Result) []int {
+	bound_by_id := make(map[int]int64, len(analytic))
+	for _, result := range analytic {
+		bound_by_id[result.ID] = result.WCRT_us
+	}
+
+	exceeded := []int{}
+	for _, result := range measured {
+		if bound, ok := bound_by_id[result.ID]; ok && result.WCRT_us > bound {
+			exceeded = append(exceeded, result.ID)
+		}
+	}
+
+	return exceeded
+}
+
+
+/*
+ *******************************************************************************
+ *                        Private Function Definitions                        *
+ *******************************************************************************
+*/
+
+
+// Returns chain's WCET: wcets[chain.ID] if present, otherwise
+// chain.Utilisation * chain.Period_us if Utilisation is set, otherwise
+// (0, false)
+func wcet_for (chain Chain, wcets map[int]int64) (int64, bool) {
+	if c, ok := wcets[chain.ID]; ok {
+		return c, true
+	}
+	if chain.Utilisation > 0 {
+		return int64(chain.Utilisation * float64(chain.Period_us)), true
+	}
+	return 0, false
+}
+
+// Returns an error if two chains share both Executor and Prio: the
+// recurrence above assumes hp(i) is well defined, which requires a strict
+// priority order within each executor
+func validate_distinct_priorities (chains Chains) error {
+	owner := make(map[[2]int]int, len(chains))
+	for _, chain := range chains {
+		key := [2]int{chain.Executor, chain.Prio}
+		if other_id, ok := owner[key]; ok {
+			return fmt.Errorf(
+				"chains %d and %d share Executor %d and Prio %d: priorities must be distinct per executor",
+				other_id, chain.ID, chain.Executor, chain.Prio)
+		}
+		owner[key] = chain.ID
+	}
+	return nil
+}
+
+// Returns the chains on the same Executor as chain with a higher Prio
+func higher_priority_chains (chains Chains, chain Chain) []Chain {
+	hp := []Chain{}
+	for _, other := range chains {
+		if other.ID != chain.ID && other.Executor == chain.Executor && other.Prio > chain.Prio {
+			hp = append(hp, other)
+		}
+	}
+	return hp
+}
+
+// Rounds a/b up to the nearest integer; returns 0 if b <= 0
+func ceil_div (a, b int64) int64 {
+	if b <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
@@ -0,0 +1,253 @@
+package analysis
+
+import (
+	// Standard packages
+	"math"
+	"math/bits"
+	"sort"
+)
+
+
+/*
+ *******************************************************************************
+ *                               Private Constants                             *
+ *******************************************************************************
+*/
+
+
+// Sample counts at or below this threshold get exact percentiles, read
+// directly off a sorted copy of the data
+const exact_percentile_threshold = 1000000
+
+// Number of linear sub-buckets per power-of-two octave in the approximate
+// histogram; higher values trade memory for precision. 256 sub-buckets
+// gives ~0.4% relative resolution within every octave.
+const approx_subbuckets_per_octave = 256
+
+// Number of octaves covered (2^0 .. 2^approx_histogram_octaves), sized to
+// span the full range of a non-negative int64 duration
+const approx_histogram_octaves = 63
+
+
+/*
+ *******************************************************************************
+ *                              Type Definitions                               *
+ *******************************************************************************
+*/
+
+
+// Percentile/stddev/CDF statistics derived from a chain's response times
+type response_time_stats struct {
+	P50_us, P95_us, P99_us, P999_us int64
+	Stddev_us                       float64
+	CDF                              []CDFPoint
+}
+
+// A log-bucketed, HDR-histogram-style approximation of a response-time
+// distribution, used once the sample count exceeds
+// exact_percentile_threshold. A plain equal-width histogram loses
+// resolution near the mode as soon as a few outliers stretch out the
+// observed range; bucketing each power-of-two octave into
+// approx_subbuckets_per_octave equal-width sub-buckets instead keeps
+// relative precision constant across the whole value range, however
+// skewed or long-tailed, with memory bounded by a fixed bucket count
+// rather than the sample count.
+type histogram struct {
+	counts []int64
+	total  int64
+}
+
+
+/*
+ *******************************************************************************
+ *                         Private Function Definitions                        *
+ *******************************************************************************
+*/
+
+
+// Computes percentile, stddev and (optionally) CDF statistics for a chain's
+// response times. Exact when len(response_times) <= exact_percentile_threshold,
+// otherwise approximated via a bounded histogram. response_times is not mutated.
+func compute_stats (response_times []int64, emit_cdf bool) response_time_stats {
+	var stats response_time_stats
+
+	if len(response_times) <= exact_percentile_threshold {
+		sorted := make([]int64, len(response_times))
+		copy(sorted, response_times)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats.P50_us = percentile_exact(sorted, 0.50)
+		stats.P95_us = percentile_exact(sorted, 0.95)
+		stats.P99_us = percentile_exact(sorted, 0.99)
+		stats.P999_us = percentile_exact(sorted, 0.999)
+
+		if emit_cdf {
+			stats.CDF = cdf_exact(sorted)
+		}
+	} else {
+		h := build_histogram(response_times)
+
+		stats.P50_us = h.percentile(0.50)
+		stats.P95_us = h.percentile(0.95)
+		stats.P99_us = h.percentile(0.99)
+		stats.P999_us = h.percentile(0.999)
+
+		if emit_cdf {
+			stats.CDF = h.cdf()
+		}
+	}
+
+	stats.Stddev_us = stddev(response_times)
+
+	return stats
+}
+
+// Returns the value at percentile p (0..1) of a sorted slice
+func percentile_exact (sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+
+	return sorted[rank]
+}
+
+// Builds the exact CDF of a sorted slice, collapsing consecutive equal values
+func cdf_exact (sorted []int64) []CDFPoint {
+	points := []CDFPoint{}
+	n := float64(len(sorted))
+
+	for i, v := range sorted {
+		if (i+1) < len(sorted) && sorted[i+1] == v {
+			continue
+		}
+		points = append(points, CDFPoint{T_us: v, CumFraction: float64(i+1) / n})
+	}
+
+	return points
+}
+
+// Returns the population standard deviation of a slice of response times
+func stddev (xs []int64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	var sum int64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := float64(sum) / float64(len(xs))
+
+	var variance float64
+	for _, x := range xs {
+		d := float64(x) - mean
+		variance += d * d
+	}
+	variance /= float64(len(xs))
+
+	return math.Sqrt(variance)
+}
+
+// Builds a log-bucketed histogram of xs
+func build_histogram (xs []int64) *histogram {
+	h := &histogram{counts: make([]int64, (approx_histogram_octaves+1)*approx_subbuckets_per_octave)}
+
+	for _, x := range xs {
+		h.counts[bucket_index(x)]++
+		h.total++
+	}
+
+	return h
+}
+
+// Returns the approximate value at percentile p (0..1): the upper edge of
+// the bucket where the cumulative count first reaches p
+func (h *histogram) percentile (p float64) int64 {
+	target := int64(math.Ceil(p * float64(h.total)))
+
+	var cum int64
+	for i, c := range h.counts {
+		cum += c
+		if cum >= target {
+			return bucket_upper_bound(i)
+		}
+	}
+
+	return bucket_upper_bound(len(h.counts) - 1)
+}
+
+// Returns an approximate CDF, one point per non-empty bucket
+func (h *histogram) cdf () []CDFPoint {
+	points := []CDFPoint{}
+
+	var cum int64
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		cum += c
+		points = append(points, CDFPoint{
+			T_us:        bucket_upper_bound(i),
+			CumFraction: float64(cum) / float64(h.total),
+		})
+	}
+
+	return points
+}
+
+// Returns the octave (bit length) of x, treating x <= 0 as octave 0; octave
+// k covers the value range [2^(k-1), 2^k - 1] for k >= 1
+func octave_of (x int64) int {
+	if x <= 0 {
+		return 0
+	}
+	return bits.Len64(uint64(x))
+}
+
+// Returns the histogram bucket index for x: octave*approx_subbuckets_per_octave
+// plus a linear sub-bucket within that octave
+func bucket_index (x int64) int {
+	octave := octave_of(x)
+	if octave == 0 {
+		return 0
+	}
+
+	lo := int64(1) << uint(octave-1)
+	width := lo / int64(approx_subbuckets_per_octave)
+	if width < 1 {
+		width = 1
+	}
+
+	sub := int((x - lo) / width)
+	if sub >= approx_subbuckets_per_octave {
+		sub = approx_subbuckets_per_octave - 1
+	}
+
+	return octave*approx_subbuckets_per_octave + sub
+}
+
+// Returns the upper edge of the value range covered by bucket index idx
+func bucket_upper_bound (idx int) int64 {
+	octave := idx / approx_subbuckets_per_octave
+	sub := idx % approx_subbuckets_per_octave
+
+	if octave == 0 {
+		return 0
+	}
+
+	lo := int64(1) << uint(octave-1)
+	width := lo / int64(approx_subbuckets_per_octave)
+	if width < 1 {
+		width = 1
+	}
+
+	return lo + int64(sub+1)*width
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	// Standard packages
+	"math"
+	"testing"
+)
+
+
+func TestPercentileExactKnownSet (t *testing.T) {
+	// sorted[i] = 10*(i+1), for i in [0, 9]
+	sorted := []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+
+	cases := []struct {
+		p    float64
+		want int64
+	}{
+		{0.10, 10},
+		{0.50, 50},
+		{0.95, 100},
+		{0.99, 100},
+		{1.00, 100},
+	}
+
+	for _, c := range cases {
+		if got := percentile_exact(sorted, c.p); got != c.want {
+			t.Fatalf("percentile_exact(p=%.2f): expected %d, got %d", c.p, c.want, got)
+		}
+	}
+}
+
+// compute_stats must take the exact, sorted-slice path at and below
+// exact_percentile_threshold, and the approximate histogram path just
+// above it; results should agree closely, since the underlying data is
+// identical apart from one extra sample.
+func TestComputeStatsExactBoundary (t *testing.T) {
+	exact := make([]int64, exact_percentile_threshold)
+	for i := range exact {
+		exact[i] = int64(i + 1)
+	}
+
+	exact_stats := compute_stats(exact, false)
+
+	// Values 1..N sorted ascending: P50 = ceil(0.5*N)
+	want_p50 := int64(exact_percentile_threshold / 2)
+	if exact_stats.P50_us != want_p50 {
+		t.Fatalf("exact path: expected P50_us %d, got %d", want_p50, exact_stats.P50_us)
+	}
+
+	approx := make([]int64, exact_percentile_threshold+1)
+	copy(approx, exact)
+	approx[exact_percentile_threshold] = int64(exact_percentile_threshold + 1)
+
+	approx_stats := compute_stats(approx, false)
+
+	// The histogram approximation should land within ~1% of the exact
+	// value; this also exercises that the one-sample-over-threshold case
+	// takes the histogram branch rather than panicking or hanging.
+	tolerance := float64(want_p50) * 0.01
+	if math.Abs(float64(approx_stats.P50_us-want_p50)) > tolerance {
+		t.Fatalf("approximate path: expected P50_us near %d (+/- %.0f), got %d",
+			want_p50, tolerance, approx_stats.P50_us)
+	}
+}
+
+func TestReduceResponseTimesStddevAndJitter (t *testing.T) {
+	chain := Chain{ID: 1, Period_us: 100}
+	response_times := []int64{10, 20, 30}
+
+	result := reduce_response_times(chain, response_times, AnalyseOptions{})
+
+	if result.BCRT_us != 10 || result.WCRT_us != 30 || result.ACRT_us != 20 {
+		t.Fatalf("expected BCRT/ACRT/WCRT 10/20/30, got %d/%d/%d",
+			result.BCRT_us, result.ACRT_us, result.WCRT_us)
+	}
+	if result.Jitter_us != 20 {
+		t.Fatalf("expected Jitter_us 20 (WCRT-BCRT), got %d", result.Jitter_us)
+	}
+	if result.Samples != 3 {
+		t.Fatalf("expected Samples 3, got %d", result.Samples)
+	}
+
+	// Population stddev of {10, 20, 30}: mean 20, variance (100+0+100)/3
+	want_stddev := math.Sqrt(200.0 / 3.0)
+	if math.Abs(result.Stddev_us-want_stddev) > 1e-9 {
+		t.Fatalf("expected Stddev_us %.6f, got %.6f", want_stddev, result.Stddev_us)
+	}
+
+	if result.DeadlineMisses != 0 || result.DeadlineMissRatio != 0 {
+		t.Fatalf("expected no deadline misses against Period_us 100, got %+v", result)
+	}
+}
+
+func TestReduceResponseTimesDeadlineMissRatio (t *testing.T) {
+	chain := Chain{ID: 1, Period_us: 100, Deadline_us: 15}
+	response_times := []int64{10, 20, 30}
+
+	result := reduce_response_times(chain, response_times, AnalyseOptions{})
+
+	if result.DeadlineMisses != 2 {
+		t.Fatalf("expected 2 deadline misses against Deadline_us 15, got %d", result.DeadlineMisses)
+	}
+
+	want_ratio := 2.0 / 3.0
+	if math.Abs(result.DeadlineMissRatio-want_ratio) > 1e-9 {
+		t.Fatalf("expected DeadlineMissRatio %.6f, got %.6f", want_ratio, result.DeadlineMissRatio)
+	}
+}